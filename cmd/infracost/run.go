@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"strings"
@@ -51,35 +52,68 @@ func addDeprecatedRunFlags(cmd *cobra.Command) {
 }
 
 func addRunInputFlags(cmd *cobra.Command) {
-	cmd.Flags().String("path", "", "Path to the code directory or file")
+	cmd.Flags().String("path", "", "Path to the Terraform code directory. Also accepts a git::/s3::/https:// module address")
 	cmd.Flags().String("config-file", "", "Path to the Infracost config file. Cannot be used with other flags")
 	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
 	cmd.Flags().String("terraform-plan-flags", "", "Flags to pass to the 'terraform plan' command")
+	cmd.Flags().String("inline-hcl", "", "Path to a single .tf file to estimate without a materialized workspace")
+	cmd.Flags().String("inline-hcl-vars-file", "", "Path to a terraform.tfvars file to use with --inline-hcl")
 }
 
 func addRunOutputFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("show-skipped", false, "Show unsupported resources, some of which might be free. Ignored for JSON outputs")
+	cmd.Flags().String("cost-change-threshold", "", "Monthly cost delta a resource must exceed to be reported as a failure with --format junit")
+	cmd.Flags().String("fail-on", "", `Expression (e.g. "diff > 100") evaluated against each resource's cost diff for --format junit, takes precedence over --cost-change-threshold`)
+	cmd.Flags().Bool("json-progress", false, "Shorthand for --format stream-json")
 }
 
 func runMain(cfg *config.Config) error {
+	streaming := isStreamingFormat(cfg)
+
+	var streamOut io.Writer = os.Stdout
+	if streaming {
+		if path := streamOutputPath(cfg); path != "" {
+			f, err := os.Create(path)
+			if err != nil {
+				return errors.Wrap(err, "Error creating stream-json output file")
+			}
+			defer f.Close() // nolint:errcheck
+
+			streamOut = f
+		}
+	}
+
+	reporter := output.NewProgressReporter(streaming, streamOut, ui.SpinnerOptions{
+		EnableLogging: cfg.IsLogging(),
+		NoColor:       cfg.NoColor,
+	})
+
 	projects := make([]*schema.Project, 0)
 
-	for _, projectCfg := range cfg.Projects.Terraform {
-		cfg.Environment.SetTerraformEnvironment(projectCfg)
+	for _, projectCfg := range cfg.Projects.All() {
+		switch p := projectCfg.(type) {
+		case *config.TerraformProject:
+			cfg.Environment.SetTerraformEnvironment(p)
+		case *config.TerraformCloudProject:
+			cfg.Environment.SetTerraformCloudEnvironment(p)
+		}
 
 		provider := providers.Detect(cfg, projectCfg)
 		if provider == nil {
 			return errors.New("Could not detect path type")
 		}
 
-		m := fmt.Sprintf("Detected %s at %s", provider.Type(), projectCfg.Path)
-		if cfg.IsLogging() {
-			log.Info(m)
-		} else {
-			fmt.Fprintln(os.Stderr, m)
+		reporter.ProjectDetected(provider.Type(), projectCfg.Name())
+		if !streaming {
+			m := fmt.Sprintf("Detected %s at %s", provider.Type(), projectCfg.Name())
+			if cfg.IsLogging() {
+				log.Info(m)
+			} else {
+				fmt.Fprintln(os.Stderr, m)
+			}
 		}
 
-		u, err := usage.LoadFromFile(projectCfg.UsageFile)
+		u, err := usage.LoadFromFile(projectCfg.GetUsageFile())
 		if err != nil {
 			return err
 		}
@@ -91,19 +125,16 @@ func runMain(cfg *config.Config) error {
 		if err != nil {
 			return err
 		}
+		project.Name = projectCfg.Name()
 
-		projects = append(projects, project)
-	}
+		reporter.ResourcesLoaded(len(project.Resources))
 
-	spinnerOpts := ui.SpinnerOptions{
-		EnableLogging: cfg.IsLogging(),
-		NoColor:       cfg.NoColor,
+		projects = append(projects, project)
 	}
-	spinner := ui.NewSpinner("Calculating cost estimate", spinnerOpts)
 
 	for _, project := range projects {
 		if err := prices.PopulatePrices(cfg, project); err != nil {
-			spinner.Fail()
+			reporter.Fail()
 			fmt.Fprintln(os.Stderr, "")
 
 			if e := unwrapped(err); errors.Is(e, prices.ErrInvalidAPIKey) {
@@ -125,11 +156,15 @@ func runMain(cfg *config.Config) error {
 			return err
 		}
 
+		reporter.PricesFetched(project.Name)
+
 		schema.CalculateCosts(project)
 		project.CalculateDiff()
+
+		reporter.CostCalculated()
 	}
 
-	spinner.Success()
+	reporter.Success()
 
 	r := output.ToOutputFormat(projects)
 
@@ -137,8 +172,10 @@ func runMain(cfg *config.Config) error {
 		cfg.Environment.SetOutputEnvironment(outputCfg)
 
 		opts := output.Options{
-			ShowSkipped: outputCfg.ShowSkipped,
-			NoColor:     cfg.NoColor,
+			ShowSkipped:         outputCfg.ShowSkipped,
+			NoColor:             cfg.NoColor,
+			CostChangeThreshold: outputCfg.CostChangeThreshold,
+			FailOn:              outputCfg.FailOn,
 		}
 
 		var (
@@ -157,6 +194,21 @@ func runMain(cfg *config.Config) error {
 		case "diff":
 			b, err = output.ToDiff(r, opts)
 			out = fmt.Sprintf("\n%s", string(b))
+		case "junit":
+			b, err = output.ToJUnit(r, opts)
+			out = string(b)
+		case "stream-json":
+			// The terminal result event joins the same ndjson stream the
+			// ProgressReporter already wrote progress events to (streamOut),
+			// rather than going through the write-the-whole-file handling
+			// below, so stream-json never gets split across stdout and a
+			// file when outputCfg.Path is set.
+			b, err = output.ToStreamResult(r, opts)
+			if err != nil {
+				return errors.Wrap(err, "Error generating output")
+			}
+			fmt.Fprintln(streamOut, string(b))
+			continue
 		case "table_deprecated":
 			b, err = output.ToTableDeprecated(r, opts)
 			out = fmt.Sprintf("\n%s", string(b))
@@ -187,10 +239,14 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 
 	hasProjectFlags := (cmd.Flags().Changed("path") ||
 		cmd.Flags().Changed("terraform-plan-flags") ||
-		cmd.Flags().Changed("usage-file"))
+		cmd.Flags().Changed("usage-file") ||
+		cmd.Flags().Changed("inline-hcl"))
 
 	hasOutputFlags := (cmd.Flags().Changed("format") ||
-		cmd.Flags().Changed("show-skipped"))
+		cmd.Flags().Changed("show-skipped") ||
+		cmd.Flags().Changed("cost-change-threshold") ||
+		cmd.Flags().Changed("fail-on") ||
+		cmd.Flags().Changed("json-progress"))
 
 	if hasConfigFile && hasProjectFlags {
 		ui.PrintUsageErrorAndExit(cmd, "--config-file flag cannot be used with other project and output flags")
@@ -232,11 +288,19 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 		projectCfg.UseState, _ = cmd.Flags().GetBool("terraform-use-state")
 		projectCfg.PlanFlags, _ = cmd.Flags().GetString("terraform-plan-flags")
 		projectCfg.UsageFile, _ = cmd.Flags().GetString("usage-file")
+		projectCfg.InlineHCLFile, _ = cmd.Flags().GetString("inline-hcl")
+		projectCfg.InlineHCLVarsFile, _ = cmd.Flags().GetString("inline-hcl-vars-file")
 	}
 
 	if hasOutputFlags {
 		outputCfg.Format, _ = cmd.Flags().GetString("format")
 		outputCfg.ShowSkipped, _ = cmd.Flags().GetBool("show-skipped")
+		outputCfg.CostChangeThreshold, _ = cmd.Flags().GetString("cost-change-threshold")
+		outputCfg.FailOn, _ = cmd.Flags().GetString("fail-on")
+
+		if jsonProgress, _ := cmd.Flags().GetBool("json-progress"); jsonProgress {
+			outputCfg.Format = "stream-json"
+		}
 	}
 
 	return nil
@@ -253,6 +317,31 @@ func checkRunConfig(cfg *config.Config) error {
 	return nil
 }
 
+// isStreamingFormat reports whether any configured output wants
+// --format stream-json, in which case the progress messages normally
+// printed ad-hoc to the spinner/stderr are replaced by ndjson events.
+func isStreamingFormat(cfg *config.Config) bool {
+	for _, o := range cfg.Outputs {
+		if strings.ToLower(o.Format) == "stream-json" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// streamOutputPath returns the configured stream-json output's Path, or ""
+// if it has none (in which case the stream goes to stdout).
+func streamOutputPath(cfg *config.Config) string {
+	for _, o := range cfg.Outputs {
+		if strings.ToLower(o.Format) == "stream-json" {
+			return o.Path
+		}
+	}
+
+	return ""
+}
+
 func unwrapped(err error) error {
 	e := err
 	for errors.Unwrap(e) != nil {
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Config is the root of both the CLI flags and the config file; it is built
+// up by loadRunFlags/LoadFromFile/LoadFromEnv before runMain uses it.
+type Config struct {
+	NoColor     bool
+	Projects    Projects
+	Outputs     []*Output
+	Environment *Environment
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Environment: &Environment{},
+	}
+}
+
+// IsLogging returns true if logs should be written instead of the ad-hoc
+// spinner/stderr messages runMain prints by default.
+func (c *Config) IsLogging() bool {
+	return os.Getenv("INFRACOST_LOG_LEVEL") != ""
+}
+
+// LoadFromFile reads a YAML config file describing the projects and outputs
+// for a run. loadRunFlags rejects combining it with the --path family of
+// project flags; output flags given alongside it replace the file's outputs.
+func (c *Config) LoadFromFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "Error reading config file")
+	}
+
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return errors.Wrap(err, "Error parsing config file")
+	}
+
+	return nil
+}
+
+// LoadFromEnv applies any INFRACOST_* environment variable overrides on top
+// of whatever flags/config file have already been loaded.
+func (c *Config) LoadFromEnv() error {
+	if v := os.Getenv("INFRACOST_NO_COLOR"); v != "" {
+		c.NoColor = true
+	}
+
+	return nil
+}
+
+// CredentialsFilePath returns the path to the file that stores the user's
+// Infracost API key, e.g. ~/.config/infracost/credentials.yml.
+func CredentialsFilePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+
+	return filepath.Join(home, ".config", "infracost", "credentials.yml")
+}
@@ -0,0 +1,23 @@
+package config
+
+// Environment tracks a handful of facts about how infracost is being run so
+// they can be attached to the anonymous usage events we send.
+type Environment struct {
+	HasUsageFile bool
+}
+
+// SetTerraformEnvironment records environment facts for a local Terraform
+// project right before it is detected and loaded.
+func (e *Environment) SetTerraformEnvironment(p *TerraformProject) {
+	e.HasUsageFile = e.HasUsageFile || p.UsageFile != ""
+}
+
+// SetTerraformCloudEnvironment records environment facts for a project that
+// is pulled from a Terraform Cloud/Enterprise workspace.
+func (e *Environment) SetTerraformCloudEnvironment(p *TerraformCloudProject) {
+	e.HasUsageFile = e.HasUsageFile || p.UsageFile != ""
+}
+
+// SetOutputEnvironment is called for each requested output. It records no
+// facts about the output.
+func (e *Environment) SetOutputEnvironment(o *Output) {}
@@ -0,0 +1,17 @@
+package config
+
+// Output describes where and how a Root should be rendered.
+type Output struct {
+	Format      string
+	Path        string
+	ShowSkipped bool
+
+	// CostChangeThreshold is the monthly cost delta a resource must exceed
+	// to be reported as a failure with --format junit.
+	CostChangeThreshold string
+
+	// FailOn is an optional expression (e.g. "diff > 100") evaluated against
+	// every resource's monthly cost diff, taking precedence over
+	// CostChangeThreshold for --format junit.
+	FailOn string
+}
@@ -0,0 +1,68 @@
+package config
+
+import "strings"
+
+// ProjectConfig is implemented by every supported project configuration
+// (TerraformProject, TerraformCloudProject, ...) so runMain can detect and
+// load them uniformly via providers.Detect.
+type ProjectConfig interface {
+	// Name is a human-readable identifier for the project, used in log/error
+	// messages and as the output project name.
+	Name() string
+	// GetUsageFile returns the path to this project's usage file, if any.
+	GetUsageFile() string
+}
+
+// TerraformProject is a project backed by a Terraform code directory (a local
+// one, or a remote module or inline HCL materialized into one), driven by
+// default via the local `terraform` binary (see PlanMode). Plan JSON files
+// and plan files are not read.
+type TerraformProject struct {
+	Path      string
+	UseState  bool
+	PlanFlags string
+	UsageFile string
+
+	// PlanMode selects how this project's plan is produced. The zero value
+	// ("" / "cli") shells out to the `terraform` binary as before;
+	// "embedded" drives a `terraform` binary found on PATH through tfexec
+	// (including its init), and without one resolves the HCL in-process, so
+	// no `terraform` binary is required.
+	PlanMode string
+
+	// InlineHCLFile, when set, points to a single .tf file to estimate
+	// without first materializing a workspace on disk. InlineHCLVarsFile is
+	// an optional terraform.tfvars to use alongside it. Path may also be set
+	// to an "inline:<hcl>" value with the same effect, for the config-file
+	// form of this feature.
+	InlineHCLFile     string
+	InlineHCLVarsFile string
+}
+
+// InlinePathPrefix marks a Path value as containing literal HCL rather than
+// a directory, e.g. `path: "inline:resource \"aws_instance\" ... "`.
+const InlinePathPrefix = "inline:"
+
+// IsRemoteModule reports whether Path is a go-getter module address
+// (git::, s3::, https://, ...) rather than a local directory.
+func (p *TerraformProject) IsRemoteModule() bool {
+	for _, prefix := range []string{"git::", "s3::", "http://", "https://"} {
+		if strings.HasPrefix(p.Path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsInlineHCL reports whether this project should be estimated from inline
+// HCL (via --inline-hcl or an "inline:" Path) rather than a directory.
+func (p *TerraformProject) IsInlineHCL() bool {
+	return p.InlineHCLFile != "" || strings.HasPrefix(p.Path, InlinePathPrefix)
+}
+
+// PlanModeEmbedded is the PlanMode that skips the terraform binary entirely.
+const PlanModeEmbedded = "embedded"
+
+func (p *TerraformProject) Name() string        { return p.Path }
+func (p *TerraformProject) GetUsageFile() string { return p.UsageFile }
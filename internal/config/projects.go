@@ -0,0 +1,23 @@
+package config
+
+// Projects holds every project configuration for a run, grouped by kind.
+type Projects struct {
+	Terraform      []*TerraformProject
+	TerraformCloud []*TerraformCloudProject
+}
+
+// All returns every configured project as a ProjectConfig, in the order they
+// should be detected and loaded.
+func (p Projects) All() []ProjectConfig {
+	all := make([]ProjectConfig, 0, len(p.Terraform)+len(p.TerraformCloud))
+
+	for _, tf := range p.Terraform {
+		all = append(all, tf)
+	}
+
+	for _, tfc := range p.TerraformCloud {
+		all = append(all, tfc)
+	}
+
+	return all
+}
@@ -0,0 +1,34 @@
+package config
+
+// TerraformCloudProject is a project whose plan is pulled from a Terraform
+// Cloud/Enterprise workspace instead of being generated locally. Exactly one
+// of Workspace or WorkspaceTag should be set; WorkspaceTag selects the first
+// workspace in Organization carrying that tag. Estimating every tagged
+// workspace as its own project isn't supported yet — one Provider maps to
+// exactly one workspace, the same as one Provider maps to one Project.
+type TerraformCloudProject struct {
+	Organization string
+	Workspace    string
+	WorkspaceTag string
+
+	// RunID selects a specific, already-completed run to estimate instead of
+	// the workspace's current/latest run. Used for post-hoc estimation of
+	// historical runs.
+	RunID string
+
+	// Host overrides the default app.terraform.io address, for Terraform
+	// Enterprise installs.
+	Host string
+
+	UsageFile string
+}
+
+func (p *TerraformCloudProject) Name() string {
+	if p.Workspace != "" {
+		return p.Organization + "/" + p.Workspace
+	}
+
+	return p.Organization + "/" + p.WorkspaceTag
+}
+
+func (p *TerraformCloudProject) GetUsageFile() string { return p.UsageFile }
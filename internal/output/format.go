@@ -0,0 +1,133 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// ToOutputFormat converts the loaded, priced projects into the Root shape
+// every To* render function below works from.
+func ToOutputFormat(projects []*schema.Project) Root {
+	r := Root{}
+
+	for _, p := range projects {
+		r.Projects = append(r.Projects, toProject(p))
+	}
+
+	return r
+}
+
+func toProject(p *schema.Project) Project {
+	project := Project{Name: p.Name}
+
+	for _, res := range p.Resources {
+		project.Resources = append(project.Resources, toResource(res))
+	}
+
+	return project
+}
+
+func toResource(r *schema.Resource) Resource {
+	res := Resource{
+		Name:            r.Name,
+		ResourceType:    r.ResourceType,
+		IsSkipped:       r.IsSkipped,
+		SkipMessage:     r.SkipMessage,
+		MonthlyCost:     r.MonthlyCost,
+		DiffMonthlyCost: r.DiffMonthlyCost,
+	}
+
+	for _, cc := range r.CostComponents {
+		res.CostComponents = append(res.CostComponents, CostComponent{
+			Name:        cc.Name,
+			MonthlyCost: cc.MonthlyCost,
+		})
+	}
+
+	return res
+}
+
+// ToJSON renders r as the canonical JSON representation of an estimate; the
+// same data is re-encoded as the payload of the terminal event for --format
+// stream-json.
+func ToJSON(r Root, opts Options) ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// ToTable renders r as the human-readable table shown by default.
+func ToTable(r Root, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 2, 2, ' ', 0)
+
+	for _, project := range r.Projects {
+		fmt.Fprintf(w, "Project: %s\n\n", project.Name)
+		fmt.Fprintf(w, "Name\tMonthly cost\n")
+
+		for _, res := range project.Resources {
+			if res.IsSkipped && !opts.ShowSkipped {
+				continue
+			}
+
+			fmt.Fprintf(w, "%s\t%s\n", res.Name, formatDecimal(res.MonthlyCost))
+		}
+
+		fmt.Fprintln(w)
+	}
+
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ToTableDeprecated is kept for --output table users who haven't migrated to
+// --format yet. It renders the same layout as ToTable.
+func ToTableDeprecated(r Root, opts Options) ([]byte, error) {
+	return ToTable(r, opts)
+}
+
+// ToHTML renders r as a standalone HTML cost breakdown.
+func ToHTML(r Root, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString("<html><body>\n")
+	for _, project := range r.Projects {
+		fmt.Fprintf(&buf, "<h2>%s</h2>\n<table>\n", project.Name)
+
+		for _, res := range project.Resources {
+			if res.IsSkipped && !opts.ShowSkipped {
+				continue
+			}
+
+			fmt.Fprintf(&buf, "<tr><td>%s</td><td>%s</td></tr>\n", res.Name, formatDecimal(res.MonthlyCost))
+		}
+
+		buf.WriteString("</table>\n")
+	}
+	buf.WriteString("</body></html>\n")
+
+	return buf.Bytes(), nil
+}
+
+// ToDiff renders r as the plain-text plan-style diff shown for `infracost
+// diff`, listing only resources whose cost changed. No color is applied.
+func ToDiff(r Root, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+
+	for _, project := range r.Projects {
+		for _, res := range project.Resources {
+			if res.DiffMonthlyCost == nil || res.DiffMonthlyCost.IsZero() {
+				continue
+			}
+
+			fmt.Fprintf(&buf, "~ %s\t%s/month\n", res.Name, formatDecimal(res.DiffMonthlyCost))
+		}
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,186 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string         `xml:"name,attr"`
+	Tests     int            `xml:"tests,attr"`
+	Failures  int            `xml:"failures,attr"`
+	Skipped   int            `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// ToJUnit renders r as a JUnit XML report so CI systems that already ingest
+// JUnit (GitLab, Jenkins, CircleCI test insights) can surface cost changes as
+// first-class test failures. Each project becomes a <testsuite> and each of
+// its resources becomes a <testcase>; a resource whose monthly cost diff
+// breaches opts.FailOn (or, failing that, opts.CostChangeThreshold) is
+// reported as a <failure>, and skipped/unsupported resources as <skipped>.
+// opts.FailOn is a single expression applied uniformly to every resource's
+// diff, not a per-resource override; there's no per-resource source for it
+// yet (usage files carry usage quantities, not output-rendering config).
+func ToJUnit(r Root, opts Options) ([]byte, error) {
+	threshold, err := parseThreshold(opts.CostChangeThreshold)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing cost-change-threshold")
+	}
+
+	suites := junitTestSuites{}
+
+	for _, project := range r.Projects {
+		suite := junitTestSuite{Name: project.Name}
+
+		for _, resource := range project.Resources {
+			tc := junitTestCase{
+				Name:      resource.Name,
+				ClassName: resource.ResourceType,
+			}
+
+			switch {
+			case resource.IsSkipped:
+				suite.Skipped++
+				tc.Skipped = &junitSkipped{Message: resource.SkipMessage}
+			case failsThreshold(resource, opts.FailOn, threshold):
+				suite.Failures++
+				tc.Failure = &junitFailure{
+					Message: fmt.Sprintf("monthly cost diff %s exceeds threshold", formatDecimal(resource.DiffMonthlyCost)),
+					Body:    diffBreakdown(resource),
+				}
+			}
+
+			suite.Tests++
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	out, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating JUnit XML")
+	}
+
+	return append([]byte(xml.Header), out...), nil
+}
+
+// parseThreshold turns the --cost-change-threshold value into a float64, or
+// nil if it wasn't set, meaning no resource fails on cost alone.
+func parseThreshold(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, errors.Errorf("invalid threshold %q, expected a number", s)
+	}
+
+	return &f, nil
+}
+
+// failsThreshold decides whether resource should be reported as a <failure>.
+// The failOn expression (a simple "diff > N", "diff >= N", "diff < N" or
+// "diff <= N" comparison), when set, takes precedence over the global
+// threshold. A failOn expression evalFailOn cannot parse is ignored, and the
+// threshold decides instead.
+func failsThreshold(resource Resource, failOn string, threshold *float64) bool {
+	if resource.DiffMonthlyCost == nil {
+		return false
+	}
+
+	diff, _ := resource.DiffMonthlyCost.Float64()
+
+	if failOn != "" {
+		ok, err := evalFailOn(failOn, diff)
+		if err == nil {
+			return ok
+		}
+	}
+
+	if threshold == nil {
+		return false
+	}
+
+	return diff > *threshold || diff < -*threshold
+}
+
+func evalFailOn(expr string, diff float64) (bool, error) {
+	expr = strings.TrimSpace(expr)
+
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		parts := strings.SplitN(expr, op, 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		if strings.TrimSpace(parts[0]) != "diff" {
+			continue
+		}
+
+		want, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil {
+			return false, errors.Errorf("invalid failOn expression %q", expr)
+		}
+
+		switch op {
+		case ">=":
+			return diff >= want, nil
+		case "<=":
+			return diff <= want, nil
+		case ">":
+			return diff > want, nil
+		case "<":
+			return diff < want, nil
+		}
+	}
+
+	return false, errors.Errorf("unsupported failOn expression %q", expr)
+}
+
+func diffBreakdown(resource Resource) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s: %s/month\n", resource.Name, formatDecimal(resource.DiffMonthlyCost))
+	for _, cc := range resource.CostComponents {
+		fmt.Fprintf(&b, "  %s: %s/month\n", cc.Name, formatDecimal(cc.MonthlyCost))
+	}
+
+	return b.String()
+}
+
+func formatDecimal(d *decimal.Decimal) string {
+	if d == nil {
+		return "0"
+	}
+
+	return d.String()
+}
@@ -0,0 +1,82 @@
+package output
+
+import "testing"
+
+func TestParseThreshold(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  *float64
+		expectErr bool
+	}{
+		{"empty means no threshold", "", nil, false},
+		{"valid number", "100", floatPtr(100), false},
+		{"valid negative number", "-50.5", floatPtr(-50.5), false},
+		{"not a number", "abc", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseThreshold(test.input)
+
+			if test.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if (got == nil) != (test.expected == nil) {
+				t.Fatalf("parseThreshold(%q) = %v, want %v", test.input, got, test.expected)
+			}
+			if got != nil && *got != *test.expected {
+				t.Fatalf("parseThreshold(%q) = %v, want %v", test.input, *got, *test.expected)
+			}
+		})
+	}
+}
+
+func TestEvalFailOn(t *testing.T) {
+	tests := []struct {
+		name      string
+		expr      string
+		diff      float64
+		expected  bool
+		expectErr bool
+	}{
+		{"greater than, true", "diff > 100", 150, true, false},
+		{"greater than, false", "diff > 100", 50, false, false},
+		{"greater than or equal, boundary", "diff >= 100", 100, true, false},
+		{"less than", "diff < 0", -10, true, false},
+		{"less than or equal, boundary", "diff <= -10", -10, true, false},
+		{"unsupported left-hand side", "cost > 100", 150, false, true},
+		{"unsupported operator", "diff == 100", 100, false, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := evalFailOn(test.expr, test.diff)
+
+			if test.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != test.expected {
+				t.Fatalf("evalFailOn(%q, %v) = %v, want %v", test.expr, test.diff, got, test.expected)
+			}
+		})
+	}
+}
+
+func floatPtr(f float64) *float64 { return &f }
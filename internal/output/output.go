@@ -0,0 +1,50 @@
+package output
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Options controls how a Root is rendered by the To* functions below.
+type Options struct {
+	ShowSkipped bool
+	NoColor     bool
+
+	// CostChangeThreshold is the monthly cost delta a resource's diff must
+	// exceed before ToJUnit reports it as a <failure> rather than a passing
+	// <testcase>. Empty means no resource fails on cost alone.
+	CostChangeThreshold string
+
+	// FailOn is an optional expression (e.g. "diff > 100") evaluated against
+	// every resource's monthly cost diff; it takes precedence over
+	// CostChangeThreshold when deciding whether ToJUnit should report a
+	// resource as a <failure>. Empty means only CostChangeThreshold applies.
+	FailOn string
+}
+
+// Root is the combined result of estimating every project passed to a run.
+type Root struct {
+	Projects []Project `json:"projects"`
+}
+
+// Project is a single estimated Terraform project and its resources.
+type Project struct {
+	Name      string     `json:"name"`
+	Resources []Resource `json:"resources"`
+}
+
+// Resource is a single estimated resource within a project.
+type Resource struct {
+	Name            string           `json:"name"`
+	ResourceType    string           `json:"resourceType"`
+	IsSkipped       bool             `json:"isSkipped,omitempty"`
+	SkipMessage     string           `json:"skipMessage,omitempty"`
+	MonthlyCost     *decimal.Decimal `json:"monthlyCost,omitempty"`
+	DiffMonthlyCost *decimal.Decimal `json:"diffMonthlyCost,omitempty"`
+	CostComponents  []CostComponent  `json:"costComponents,omitempty"`
+}
+
+// CostComponent is a single priced line item that makes up a Resource.
+type CostComponent struct {
+	Name        string           `json:"name"`
+	MonthlyCost *decimal.Decimal `json:"monthlyCost,omitempty"`
+}
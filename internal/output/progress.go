@@ -0,0 +1,51 @@
+package output
+
+import (
+	"io"
+	"os"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// ProgressReporter surfaces what runMain is doing as it detects projects,
+// loads resources and prices them. The default implementation drives the
+// existing spinner/stderr messages; StreamReporter emits the same
+// information as newline-delimited JSON events instead, for --format
+// stream-json.
+type ProgressReporter interface {
+	ProjectDetected(providerType, path string)
+	ResourcesLoaded(count int)
+	PricesFetched(project string)
+	CostCalculated()
+	Fail()
+	Success()
+}
+
+// NewProgressReporter picks the streaming reporter when any configured
+// output wants stream-json, otherwise the spinner used for every other
+// format. out is where stream-json's progress events are written; the
+// terminal result event belongs on the same stream, so the caller should
+// reuse out (rather than outputCfg.Path's usual write-the-whole-file
+// handling) when emitting it. Ignored when streaming is false.
+func NewProgressReporter(streaming bool, out io.Writer, opts ui.SpinnerOptions) ProgressReporter {
+	if streaming {
+		if out == nil {
+			out = os.Stdout
+		}
+
+		return newStreamReporter(out)
+	}
+
+	return &spinnerReporter{spinner: ui.NewSpinner("Calculating cost estimate", opts)}
+}
+
+type spinnerReporter struct {
+	spinner *ui.Spinner
+}
+
+func (r *spinnerReporter) ProjectDetected(providerType, path string) {}
+func (r *spinnerReporter) ResourcesLoaded(count int)                 {}
+func (r *spinnerReporter) PricesFetched(project string)              {}
+func (r *spinnerReporter) CostCalculated()                           {}
+func (r *spinnerReporter) Fail()                                     { r.spinner.Fail() }
+func (r *spinnerReporter) Success()                                  { r.spinner.Success() }
@@ -0,0 +1,79 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// streamEvent is a single newline-delimited JSON progress event emitted for
+// --format stream-json, mirroring the pattern of `terraform init -json`/
+// `plan -json`.
+type streamEvent struct {
+	Type    string      `json:"type"`
+	Count   int         `json:"count,omitempty"`
+	Project string      `json:"project,omitempty"`
+	Result  interface{} `json:"result,omitempty"`
+}
+
+// streamReporter emits ProgressReporter events as ndjson to out instead of
+// driving the spinner, so GUIs/IDE extensions/orchestration layers can
+// consume progress without screen-scraping. out is also where the terminal
+// result event (see ToStreamResult) belongs, so the two form one ordered
+// stream even when --format stream-json is configured with an output path.
+type streamReporter struct {
+	out io.Writer
+}
+
+func newStreamReporter(out io.Writer) *streamReporter {
+	return &streamReporter{out: out}
+}
+
+func (r *streamReporter) emit(e streamEvent) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+
+	fmt.Fprintln(r.out, string(b))
+}
+
+func (r *streamReporter) ProjectDetected(providerType, path string) {
+	r.emit(streamEvent{Type: "project_detected", Project: path})
+}
+
+func (r *streamReporter) ResourcesLoaded(count int) {
+	r.emit(streamEvent{Type: "resources_loaded", Count: count})
+}
+
+func (r *streamReporter) PricesFetched(project string) {
+	r.emit(streamEvent{Type: "prices_fetched", Project: project})
+}
+
+func (r *streamReporter) CostCalculated() {
+	r.emit(streamEvent{Type: "cost_calculated"})
+}
+
+func (r *streamReporter) Fail() {}
+
+func (r *streamReporter) Success() {}
+
+// ToStreamResult renders the terminal {"type":"result",...} event carrying
+// the same payload as ToJSON, ending a --format stream-json run. Like every
+// other To* function it just returns bytes; the caller should write them to
+// the same destination as the ProgressReporter's events (see streamReporter),
+// not reopen outputCfg.Path, so the whole run is one ordered ndjson stream
+// instead of being split across stdout and a file.
+func ToStreamResult(r Root, opts Options) ([]byte, error) {
+	b, err := ToJSON(r, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload interface{}
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(streamEvent{Type: "result", Result: payload})
+}
@@ -0,0 +1,27 @@
+package providers
+
+import (
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/providers/terraformcloud"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// Provider loads the resources for a single project, regardless of where its
+// Terraform plan comes from.
+type Provider interface {
+	Type() string
+	LoadResources(u map[string]*schema.UsageData) (*schema.Project, error)
+}
+
+// Detect picks the Provider implementation for projectCfg.
+func Detect(cfg *config.Config, projectCfg config.ProjectConfig) Provider {
+	switch p := projectCfg.(type) {
+	case *config.TerraformProject:
+		return terraform.NewProvider(p)
+	case *config.TerraformCloudProject:
+		return terraformcloud.NewProvider(p)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,60 @@
+package terraform
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+// runTerraformCLI shells out to `terraform init`, `terraform plan` and
+// `terraform show -json` in cfg.Path, returning the resulting plan JSON.
+func runTerraformCLI(binary string, cfg *config.TerraformProject) ([]byte, error) {
+	if err := runCmd(binary, cfg.Path, "init", "-input=false"); err != nil {
+		return nil, errors.Wrap(err, "terraform init failed")
+	}
+
+	planArgs := append([]string{"plan", "-input=false", "-out=infracost.tfplan"}, strings.Fields(cfg.PlanFlags)...)
+	if err := runCmd(binary, cfg.Path, planArgs...); err != nil {
+		return nil, errors.Wrap(err, "terraform plan failed")
+	}
+
+	out, err := outputOfCmd(binary, cfg.Path, "show", "-json", "infracost.tfplan")
+	if err != nil {
+		return nil, errors.Wrap(err, "terraform show failed")
+	}
+
+	return out, nil
+}
+
+func runCmd(binary, dir string, args ...string) error {
+	cmd := exec.Command(binary, args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return errors.New(stderr.String())
+	}
+
+	return nil
+}
+
+func outputOfCmd(binary, dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command(binary, args...)
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, errors.New(stderr.String())
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,132 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfconfig "github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/pkg/errors"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// variablesContext builds an hcl.EvalContext exposing each variable's value
+// under var.<name>: an override from overrides (sourced from -var/-var-file
+// in PlanFlags) when present, else the variable's module-default. A variable
+// with neither (or with a default goValueToCty can't convert) is left out, so
+// references to it fail to evaluate.
+func variablesContext(vars map[string]*tfconfig.Variable, overrides map[string]cty.Value) *hcl.EvalContext {
+	varVals := map[string]cty.Value{}
+
+	for name, v := range vars {
+		if val := goValueToCty(v.Default); val.Type() != cty.NilType {
+			varVals[name] = val
+		}
+	}
+	for name, v := range overrides {
+		varVals[name] = v
+	}
+
+	return &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var": cty.ObjectVal(varVals),
+		},
+	}
+}
+
+// varOverridesFromFlags extracts -var/-var-file values out of PlanFlags (the
+// same string cliPlanJSON and tfexecPlanJSON's planOptionsFromFlags read),
+// so the no-fork path honors them too instead of only ever seeing module
+// defaults.
+func varOverridesFromFlags(flags string) (map[string]cty.Value, error) {
+	overrides := map[string]cty.Value{}
+
+	for _, f := range strings.Fields(flags) {
+		switch {
+		case strings.HasPrefix(f, "-var-file="):
+			path := strings.TrimPrefix(f, "-var-file=")
+
+			vals, err := tfvarsFromFile(path)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error reading %s", f)
+			}
+			for name, v := range vals {
+				overrides[name] = v
+			}
+		case strings.HasPrefix(f, "-var="):
+			kv := strings.SplitN(strings.TrimPrefix(f, "-var="), "=", 2)
+			if len(kv) == 2 {
+				overrides[kv[0]] = cty.StringVal(kv[1])
+			}
+		}
+	}
+
+	return overrides, nil
+}
+
+// tfvarsFromFile reads a .tfvars file's top-level attributes into cty
+// values, the same shape -var-file assigns into var.<name>.
+func tfvarsFromFile(path string) (map[string]cty.Value, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, diags := hclparse.NewParser().ParseHCL(b, path)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return map[string]cty.Value{}, nil
+	}
+
+	vals := map[string]cty.Value{}
+	for name, attr := range body.Attributes {
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			continue
+		}
+
+		vals[name] = val
+	}
+
+	return vals, nil
+}
+
+func goValueToCty(v interface{}) cty.Value {
+	switch val := v.(type) {
+	case string:
+		return cty.StringVal(val)
+	case bool:
+		return cty.BoolVal(val)
+	case float64:
+		return cty.NumberFloatVal(val)
+	case int:
+		return cty.NumberIntVal(int64(val))
+	default:
+		return cty.NilVal
+	}
+}
+
+func ctyValueToGo(v cty.Value) interface{} {
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+
+	t := v.Type()
+	switch {
+	case t == cty.String:
+		return v.AsString()
+	case t == cty.Bool:
+		return v.True()
+	case t == cty.Number:
+		f, _ := v.AsBigFloat().Float64()
+		return f
+	default:
+		return nil
+	}
+}
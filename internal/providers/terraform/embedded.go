@@ -0,0 +1,134 @@
+package terraform
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	tfconfig "github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/hashicorp/terraform-exec/tfexec"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// embeddedPlanJSON produces this project's plan JSON without shelling out to
+// `terraform init`/`plan` from the command line. If a `terraform` binary is
+// on PATH it's driven in-process via tfexec (still a subprocess, but without
+// our own os/exec plumbing and log scraping); otherwise the HCL is read and
+// resolved directly, the way Crossplane's NoForkConnector drives provider
+// schemas without a binary at all.
+func (p *Provider) embeddedPlanJSON() ([]byte, error) {
+	if binary, err := exec.LookPath("terraform"); err == nil {
+		return p.tfexecPlanJSON(binary)
+	}
+
+	return p.noForkPlanJSON()
+}
+
+// tfexecPlanJSON drives the terraform binary through tfexec instead of
+// os/exec directly (init without -upgrade, then plan), and returns the plan
+// as the raw JSON string from ShowPlanFileRaw.
+func (p *Provider) tfexecPlanJSON(binary string) ([]byte, error) {
+	ctx := context.Background()
+
+	tf, err := tfexec.NewTerraform(p.cfg.Path, binary)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating tfexec runner")
+	}
+
+	if err := tf.Init(ctx, tfexec.Upgrade(false)); err != nil {
+		return nil, errors.Wrap(err, "Error running embedded terraform init")
+	}
+
+	planFile := "infracost-embedded.tfplan"
+	planOpts := []tfexec.PlanOption{tfexec.Out(planFile)}
+
+	opts, unsupported := planOptionsFromFlags(p.cfg.PlanFlags)
+	planOpts = append(planOpts, opts...)
+	for _, f := range unsupported {
+		log.Warnf("Embedded terraform plan: ignoring unsupported terraform-plan-flags entry %q", f)
+	}
+
+	if _, err := tf.Plan(ctx, planOpts...); err != nil {
+		return nil, errors.Wrap(err, "Error running embedded terraform plan")
+	}
+
+	plan, err := tf.ShowPlanFileRaw(ctx, planFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading embedded terraform plan")
+	}
+
+	return []byte(plan), nil
+}
+
+// planOptionsFromFlags translates the subset of cfg.PlanFlags that tfexec
+// has typed options for (-var, -var-file, -target) into PlanOptions, the way
+// cliPlanJSON passes the whole string straight through to `terraform plan`.
+// Anything else is returned as-is so the caller can warn instead of quietly
+// dropping it.
+func planOptionsFromFlags(flags string) ([]tfexec.PlanOption, []string) {
+	var opts []tfexec.PlanOption
+	var unsupported []string
+
+	for _, f := range strings.Fields(flags) {
+		switch {
+		case strings.HasPrefix(f, "-var-file="):
+			opts = append(opts, tfexec.VarFile(strings.TrimPrefix(f, "-var-file=")))
+		case strings.HasPrefix(f, "-var="):
+			opts = append(opts, tfexec.Var(strings.TrimPrefix(f, "-var=")))
+		case strings.HasPrefix(f, "-target="):
+			opts = append(opts, tfexec.Target(strings.TrimPrefix(f, "-target=")))
+		default:
+			unsupported = append(unsupported, f)
+		}
+	}
+
+	return opts, unsupported
+}
+
+// noForkPlanJSON resolves the module's schema and variables directly from
+// its HCL, without ever invoking a terraform binary, so that air-gapped CI
+// and module estimation without registry.terraform.io access both work. It
+// produces a plan-shaped JSON covering resources whose attributes can be
+// resolved statically; resources depending on provider-computed values still
+// need a real plan.
+func (p *Provider) noForkPlanJSON() ([]byte, error) {
+	mod, diags := tfconfig.LoadModule(p.cfg.Path)
+	if diags.HasErrors() {
+		return nil, errors.Wrap(diags.Err(), "Error inspecting Terraform module")
+	}
+
+	overrides, err := varOverridesFromFlags(p.cfg.PlanFlags)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading terraform-plan-flags var overrides")
+	}
+
+	parser := hclparse.NewParser()
+
+	var changes []resourceChange
+	for _, r := range mod.ManagedResources {
+		f, diags := parser.ParseHCLFile(r.Pos.Filename)
+		if diags.HasErrors() {
+			return nil, errors.Wrap(diags.Errs()[0], "Error parsing "+r.Pos.Filename)
+		}
+
+		if resourceUsesMetaArguments(f, r) {
+			log.Warnf("Skipping %s: count/for_each is not supported without a terraform binary", r.Type+"."+r.Name)
+			continue
+		}
+
+		attrs, err := resolveResourceAttributes(f, r, mod.Variables, overrides)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error resolving attributes for %s", r.Type+"."+r.Name)
+		}
+
+		changes = append(changes, resourceChange{
+			Address: r.Type + "." + r.Name,
+			Type:    r.Type,
+			After:   attrs,
+		})
+	}
+
+	return marshalPlanJSON(changes)
+}
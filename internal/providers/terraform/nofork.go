@@ -0,0 +1,109 @@
+package terraform
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfconfig "github.com/hashicorp/terraform-config-inspect/tfconfig"
+	log "github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// resourceChange is the minimal shape of a Terraform plan's resource_changes
+// entry that parsePlanJSON needs; marshalPlanJSON wraps a slice of these back
+// into the same plan JSON shape the CLI and tfexec paths produce.
+type resourceChange struct {
+	Address string
+	Type    string
+	After   map[string]interface{}
+}
+
+func marshalPlanJSON(changes []resourceChange) ([]byte, error) {
+	out := planJSON{}
+
+	for _, c := range changes {
+		rc := struct {
+			Address string                 `json:"address"`
+			Type    string                 `json:"type"`
+			Change  struct {
+				After map[string]interface{} `json:"after"`
+			} `json:"change"`
+		}{Address: c.Address, Type: c.Type}
+		rc.Change.After = c.After
+
+		out.ResourceChanges = append(out.ResourceChanges, rc)
+	}
+
+	return json.Marshal(out)
+}
+
+// resourceUsesMetaArguments reports whether r's resource block sets `count`
+// or `for_each`. tfconfig.ManagedResources gives one entry per resource block
+// regardless of any such multiplier, so noForkPlanJSON can't statically know
+// how many instances r actually expands to; callers skip these rather than
+// silently pricing a single instance.
+func resourceUsesMetaArguments(f *hcl.File, r *tfconfig.Resource) bool {
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return false
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "resource" || len(block.Labels) != 2 {
+			continue
+		}
+		if block.Labels[0] != r.Type || block.Labels[1] != r.Name {
+			continue
+		}
+
+		_, hasCount := block.Body.Attributes["count"]
+		_, hasForEach := block.Body.Attributes["for_each"]
+		return hasCount || hasForEach
+	}
+
+	return false
+}
+
+// resolveResourceAttributes statically evaluates a resource block's
+// attributes, substituting any `var.x` references from vars/overrides.
+// Attributes that depend on another resource's computed output, or a
+// required variable with neither a default nor an override, are left out of
+// the result and logged; pricing for those falls back to the resource's
+// defaults.
+func resolveResourceAttributes(f *hcl.File, r *tfconfig.Resource, vars map[string]*tfconfig.Variable, overrides map[string]cty.Value) (map[string]interface{}, error) {
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return map[string]interface{}{}, nil
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "resource" || len(block.Labels) != 2 {
+			continue
+		}
+		if block.Labels[0] != r.Type || block.Labels[1] != r.Name {
+			continue
+		}
+
+		return evalBlockAttributes(block, r.Type+"."+r.Name, vars, overrides), nil
+	}
+
+	return map[string]interface{}{}, nil
+}
+
+func evalBlockAttributes(block *hclsyntax.Block, address string, vars map[string]*tfconfig.Variable, overrides map[string]cty.Value) map[string]interface{} {
+	attrs := map[string]interface{}{}
+	ctx := variablesContext(vars, overrides)
+
+	for name, attr := range block.Body.Attributes {
+		val, diags := attr.Expr.Value(ctx)
+		if diags.HasErrors() {
+			log.Warnf("%s: could not statically resolve attribute %q without a terraform binary; estimate may be based on a partial/default value", address, name)
+			continue
+		}
+
+		attrs[name] = ctyValueToGo(val)
+	}
+
+	return attrs
+}
@@ -0,0 +1,43 @@
+package terraform
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// planJSON is the subset of Terraform's plan JSON representation
+// (`terraform show -json`) that we need to build resources from.
+type planJSON struct {
+	ResourceChanges []struct {
+		Address string                 `json:"address"`
+		Type    string                 `json:"type"`
+		Change  struct {
+			After map[string]interface{} `json:"after"`
+		} `json:"change"`
+	} `json:"resource_changes"`
+}
+
+// parsePlanJSON turns raw Terraform plan JSON into a schema.Project,
+// resolving each changed resource through resources.BuildResource and
+// pricing it against any matching usage data in u.
+func parsePlanJSON(raw []byte, u map[string]*schema.UsageData) (*schema.Project, error) {
+	var plan planJSON
+	if err := json.Unmarshal(raw, &plan); err != nil {
+		return nil, errors.Wrap(err, "Error unmarshalling plan JSON")
+	}
+
+	project := schema.NewProject()
+
+	for _, rc := range plan.ResourceChanges {
+		r := resources.BuildResource(rc.Type, rc.Address, rc.Change.After, u[rc.Address])
+		if r != nil {
+			project.Resources = append(project.Resources, r)
+		}
+	}
+
+	return project, nil
+}
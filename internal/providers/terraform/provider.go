@@ -0,0 +1,85 @@
+// Package terraform implements the provider that loads resources from a
+// Terraform code directory: a local one, or a remote module or inline HCL
+// materialized into one. Plan JSON files and plan files are not read.
+package terraform
+
+import (
+	"os/exec"
+
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// Provider loads a project's resources by generating a Terraform plan and
+// parsing the resulting plan JSON.
+type Provider struct {
+	cfg       *config.TerraformProject
+	sourceErr error
+	// cleanup removes any temp dir resolveSource created for cfg.Path (a
+	// materialized remote module or inline HCL), if any.
+	cleanup func()
+}
+
+// NewProvider builds a Provider for cfg, first materializing cfg.Path onto
+// local disk if it's a remote module address or inline HCL.
+func NewProvider(cfg *config.TerraformProject) *Provider {
+	cleanup, err := resolveSource(cfg)
+	if err != nil {
+		return &Provider{cfg: cfg, sourceErr: err, cleanup: cleanup}
+	}
+
+	return &Provider{cfg: cfg, cleanup: cleanup}
+}
+
+func (p *Provider) Type() string { return "terraform_dir" }
+
+// LoadResources produces this project's plan JSON and parses it into a
+// schema.Project. cfg.PlanMode picks how the plan is produced.
+func (p *Provider) LoadResources(u map[string]*schema.UsageData) (*schema.Project, error) {
+	if p.cleanup != nil {
+		defer p.cleanup()
+	}
+
+	if p.sourceErr != nil {
+		return nil, p.sourceErr
+	}
+
+	planJSON, err := p.plan()
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePlanJSON(planJSON, u)
+}
+
+// plan produces this project's plan JSON, either by shelling out to the
+// `terraform` binary (the default) or, with PlanMode set to
+// config.PlanModeEmbedded, via embeddedPlanJSON.
+func (p *Provider) plan() ([]byte, error) {
+	if p.cfg.PlanMode == config.PlanModeEmbedded {
+		return p.embeddedPlanJSON()
+	}
+
+	return p.cliPlanJSON()
+}
+
+// cliPlanJSON shells out to the `terraform` binary to init and plan this
+// project, returning the plan in JSON form.
+func (p *Provider) cliPlanJSON() ([]byte, error) {
+	binary, err := exec.LookPath("terraform")
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not find terraform binary")
+	}
+
+	return runTerraformCLI(binary, p.cfg)
+}
+
+// ParsePlanJSON turns raw Terraform plan JSON into a schema.Project, pricing
+// each resource against usage data from u where available. Both the local
+// and Terraform Cloud providers share this so a plan behaves identically
+// regardless of where it came from.
+func ParsePlanJSON(planJSON []byte, u map[string]*schema.UsageData) (*schema.Project, error) {
+	return parsePlanJSON(planJSON, u)
+}
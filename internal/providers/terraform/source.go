@@ -0,0 +1,101 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	getter "github.com/hashicorp/go-getter"
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+// resolveSource materializes cfg.Path onto local disk when it's a remote
+// module address or inline HCL, and rewrites cfg.Path to point at the
+// resulting directory. Local directories are left untouched, and cleanup is a
+// no-op. The caller must run the returned cleanup once it's done with cfg.Path
+// to remove any temp dir this created.
+func resolveSource(cfg *config.TerraformProject) (func(), error) {
+	switch {
+	case cfg.IsInlineHCL():
+		return materializeInlineHCL(cfg)
+	case cfg.IsRemoteModule():
+		return materializeRemoteModule(cfg)
+	default:
+		return func() {}, nil
+	}
+}
+
+// materializeRemoteModule fetches a git::/s3::/https:// module address into
+// a temp dir using go-getter, the same resolver Terraform itself uses for
+// module sources, then points cfg.Path at it.
+func materializeRemoteModule(cfg *config.TerraformProject) (func(), error) {
+	dir, err := ioutil.TempDir("", "infracost-module")
+	if err != nil {
+		return func() {}, errors.Wrap(err, "Error creating temp dir for remote module")
+	}
+	cleanup := func() { os.RemoveAll(dir) } // nolint:errcheck
+
+	if err := getter.Get(dir, cfg.Path); err != nil {
+		cleanup()
+		return func() {}, errors.Wrapf(err, "Error fetching module %s", cfg.Path)
+	}
+
+	cfg.Path = dir
+
+	return cleanup, nil
+}
+
+// materializeInlineHCL writes cfg's inline HCL (and optional tfvars) out as
+// a synthetic single-file project dir, so the rest of the provider can treat
+// it like any other local Terraform directory.
+func materializeInlineHCL(cfg *config.TerraformProject) (func(), error) {
+	dir, err := ioutil.TempDir("", "infracost-inline")
+	if err != nil {
+		return func() {}, errors.Wrap(err, "Error creating temp dir for inline HCL")
+	}
+	cleanup := func() { os.RemoveAll(dir) } // nolint:errcheck
+
+	hcl, err := inlineHCLContent(cfg)
+	if err != nil {
+		cleanup()
+		return func() {}, err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "main.tf"), hcl, 0644); err != nil { // nolint:gosec
+		cleanup()
+		return func() {}, errors.Wrap(err, "Error writing inline main.tf")
+	}
+
+	if cfg.InlineHCLVarsFile != "" {
+		tfvars, err := ioutil.ReadFile(cfg.InlineHCLVarsFile)
+		if err != nil {
+			cleanup()
+			return func() {}, errors.Wrap(err, "Error reading --inline-hcl-vars-file")
+		}
+
+		if err := ioutil.WriteFile(filepath.Join(dir, "terraform.tfvars"), tfvars, 0644); err != nil { // nolint:gosec
+			cleanup()
+			return func() {}, errors.Wrap(err, "Error writing inline terraform.tfvars")
+		}
+	}
+
+	cfg.Path = dir
+
+	return cleanup, nil
+}
+
+func inlineHCLContent(cfg *config.TerraformProject) ([]byte, error) {
+	if strings.HasPrefix(cfg.Path, config.InlinePathPrefix) {
+		return []byte(strings.TrimPrefix(cfg.Path, config.InlinePathPrefix)), nil
+	}
+
+	b, err := ioutil.ReadFile(cfg.InlineHCLFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading --inline-hcl file")
+	}
+
+	return b, nil
+}
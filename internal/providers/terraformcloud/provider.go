@@ -0,0 +1,175 @@
+// Package terraformcloud implements a provider that pulls a Terraform plan
+// from a Terraform Cloud/Enterprise workspace via the Runs and Plans API,
+// rather than invoking the local `terraform` binary.
+package terraformcloud
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const defaultHost = "app.terraform.io"
+
+// pollInterval and pollTimeout bound how long Provider.LoadResources waits
+// for a speculative run to finish planning.
+const (
+	pollInterval = 5 * time.Second
+	pollTimeout  = 10 * time.Minute
+)
+
+// Provider loads a project's resources from a Terraform Cloud/Enterprise
+// workspace's latest run, or a specific run selected by cfg.RunID, instead of
+// running `terraform plan` locally. Reading a workspace's current state
+// directly (without a run) isn't supported yet; every estimate goes through
+// the Runs/Plans API.
+type Provider struct {
+	cfg       *config.TerraformCloudProject
+	client    *tfe.Client
+	clientErr error
+}
+
+// NewProvider builds a Provider for cfg, authenticating with the TFE_TOKEN
+// environment variable against cfg.Host. Credentials stored by the terraform
+// CLI are not read.
+func NewProvider(cfg *config.TerraformCloudProject) *Provider {
+	host := cfg.Host
+	if host == "" {
+		host = defaultHost
+	}
+
+	client, err := tfe.NewClient(&tfe.Config{
+		Address: fmt.Sprintf("https://%s", host),
+		Token:   os.Getenv("TFE_TOKEN"),
+	})
+	if err != nil {
+		return &Provider{cfg: cfg, clientErr: errors.Wrap(err, "Error creating Terraform Cloud client")}
+	}
+
+	return &Provider{cfg: cfg, client: client}
+}
+
+func (p *Provider) Type() string { return "terraform_cloud" }
+
+// LoadResources fetches the plan JSON for the configured workspace/run and
+// feeds it into the existing Terraform plan-parser.
+func (p *Provider) LoadResources(u map[string]*schema.UsageData) (*schema.Project, error) {
+	if p.clientErr != nil {
+		return nil, p.clientErr
+	}
+
+	ctx := context.Background()
+
+	run, err := p.resolveRun(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error resolving Terraform Cloud run")
+	}
+
+	planJSON, err := p.downloadPlanJSON(ctx, run)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error downloading Terraform Cloud plan JSON")
+	}
+
+	return terraform.ParsePlanJSON(planJSON, u)
+}
+
+// resolveRun returns the run to estimate: the one selected by cfg.RunID, or
+// else the workspace's latest run, polling until it finishes planning.
+func (p *Provider) resolveRun(ctx context.Context) (*tfe.Run, error) {
+	ws, err := p.workspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.cfg.RunID != "" {
+		run, err := p.client.Runs.Read(ctx, p.cfg.RunID)
+		if err != nil {
+			return nil, err
+		}
+
+		return p.waitForPlan(ctx, run)
+	}
+
+	runs, err := p.client.Runs.List(ctx, ws.ID, &tfe.RunListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(runs.Items) == 0 {
+		return nil, errors.Errorf("workspace %s has no runs", ws.Name)
+	}
+
+	return p.waitForPlan(ctx, runs.Items[0])
+}
+
+// workspace resolves the workspace to estimate, selecting by name
+// (cfg.Workspace) or, if that's unset, by the first workspace in
+// cfg.Organization carrying cfg.WorkspaceTag.
+func (p *Provider) workspace(ctx context.Context) (*tfe.Workspace, error) {
+	if p.cfg.Workspace != "" {
+		return p.client.Workspaces.Read(ctx, p.cfg.Organization, p.cfg.Workspace)
+	}
+
+	if p.cfg.WorkspaceTag == "" {
+		return nil, errors.New("one of Workspace or WorkspaceTag must be set")
+	}
+
+	list, err := p.client.Workspaces.List(ctx, p.cfg.Organization, &tfe.WorkspaceListOptions{
+		Tags: p.cfg.WorkspaceTag,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return nil, errors.Errorf("no workspaces in %s tagged %q", p.cfg.Organization, p.cfg.WorkspaceTag)
+	}
+
+	return list.Items[0], nil
+}
+
+// waitForPlan polls run until its plan has finished, so a speculative run
+// triggered moments ago can still be estimated. It also covers cfg.RunID
+// selecting a historical run: that run's plan may have already errored, been
+// canceled, or never run at all, so those terminal states are rejected with
+// a clear error instead of being read as a finished plan later on.
+func (p *Provider) waitForPlan(ctx context.Context, run *tfe.Run) (*tfe.Run, error) {
+	deadline := time.Now().Add(pollTimeout)
+
+	for {
+		if run.Plan != nil {
+			switch run.Plan.Status {
+			case tfe.PlanFinished:
+				return run, nil
+			case tfe.PlanErrored, tfe.PlanCanceled, tfe.PlanUnreachable:
+				return nil, errors.Errorf("run %s's plan did not finish successfully (status %s)", run.ID, run.Plan.Status)
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return nil, errors.Errorf("timed out waiting for run %s to finish planning", run.ID)
+		}
+
+		time.Sleep(pollInterval)
+
+		var err error
+		run, err = p.client.Runs.Read(ctx, run.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *Provider) downloadPlanJSON(ctx context.Context, run *tfe.Run) ([]byte, error) {
+	if run.Plan == nil {
+		return nil, errors.Errorf("run %s has no plan", run.ID)
+	}
+
+	return p.client.Plans.ReadJSONOutput(ctx, run.Plan.ID)
+}
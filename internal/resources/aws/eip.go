@@ -13,46 +13,158 @@ type EIP struct {
 	CustomerOwnedIPv4Pool string
 	Instance              string
 	NetworkInterface      string
+
+	// MonthlyHoursUnused is the number of hours per month the EIP is either
+	// unattached, or attached to a stopped instance, or one of more-than-one
+	// EIPs attached to a running instance — the conditions under which AWS
+	// charges ElasticIP:IdleAddress. Defaults to 730 (unattached) or 0
+	// (attached), since an attached EIP on a running instance is free unless
+	// the usage file says otherwise.
+	MonthlyHoursUnused *float64 `infracost_usage:"monthly_hours_unused"`
+	// MonthlyAdditionalRemaps is the total number of times the EIP is
+	// remapped to another instance/network interface per month. AWS's first
+	// freeMonthlyRemaps remaps each month are free; additionalRemaps
+	// subtracts that free tier before billing the rest as
+	// ElasticIP:AdditionalAddress.
+	MonthlyAdditionalRemaps *int64 `infracost_usage:"monthly_additional_remaps"`
+	// MonthlyDataProcessedGB is the data processed through the EIP per
+	// month, e.g. when it fronts a NAT instance or PrivateLink endpoint.
+	MonthlyDataProcessedGB *float64 `infracost_usage:"monthly_data_processed_gb"`
 }
 
-var EIPUsageSchema = []*schema.UsageItem{}
+// freeMonthlyRemaps is AWS's free tier for EIP remaps per month, beyond
+// which ElasticIP:AdditionalAddress is charged.
+const freeMonthlyRemaps int64 = 100
+
+var EIPUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_hours_unused", ValueType: schema.Float64, DefaultValue: 0},
+	{Key: "monthly_additional_remaps", ValueType: schema.Int64, DefaultValue: 0},
+	{Key: "monthly_data_processed_gb", ValueType: schema.Float64, DefaultValue: 0},
+}
 
 func (r *EIP) PopulateUsage(u *schema.UsageData) {
 	resources.PopulateArgsWithUsage(r, u)
 }
 
+func (r *EIP) isAttached() bool {
+	return r.Instance != "" || r.NetworkInterface != ""
+}
+
 func (r *EIP) BuildResource() *schema.Resource {
+	costComponents := []*schema.CostComponent{r.idleAddressCostComponent()}
+
+	if remaps := r.additionalRemaps(); remaps > 0 {
+		costComponents = append(costComponents, r.additionalRemapsCostComponent(remaps))
+	}
 
-	if r.CustomerOwnedIPv4Pool != "" || r.Instance != "" || r.NetworkInterface != "" {
-		return &schema.Resource{
-			Name:        r.Address,
-			NoPrice:     true,
-			IsSkipped:   true,
-			UsageSchema: EIPUsageSchema,
-		}
+	if r.MonthlyDataProcessedGB != nil {
+		costComponents = append(costComponents, r.dataProcessedCostComponent())
 	}
 
 	return &schema.Resource{
-		Name: r.Address,
-		CostComponents: []*schema.CostComponent{
-			{
-				Name:           "IP address (if unused)",
-				Unit:           "hours",
-				UnitMultiplier: decimal.NewFromInt(1),
-				HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
-				ProductFilter: &schema.ProductFilter{
-					VendorName:    strPtr("aws"),
-					Region:        strPtr(r.Region),
-					Service:       strPtr("AmazonEC2"),
-					ProductFamily: strPtr("IP Address"),
-					AttributeFilters: []*schema.AttributeFilter{
-						{Key: "usagetype", ValueRegex: strPtr("/ElasticIP:IdleAddress/")},
-					},
-				},
-				PriceFilter: &schema.PriceFilter{
-					StartUsageAmount: strPtr("1"),
-				},
+		Name:           r.Address,
+		CostComponents: costComponents,
+		UsageSchema:    EIPUsageSchema,
+	}
+}
+
+// idleAddressCostComponent charges ElasticIP:IdleAddress for the hours the
+// address was unused: an unattached EIP defaults to the full 730 hours/month,
+// an attached one defaults to 0 since it's normally free, and either can be
+// overridden with monthly_hours_unused (e.g. an instance that's stopped part
+// of the month, or one of several EIPs on a running instance). A BYOIP pool
+// address is billed under a separate usage type from a standard IPv4 address.
+func (r *EIP) idleAddressCostComponent() *schema.CostComponent {
+	hours := decimal.NewFromInt(730)
+	if r.isAttached() {
+		hours = decimal.NewFromInt(0)
+	}
+	if r.MonthlyHoursUnused != nil {
+		hours = decimal.NewFromFloat(*r.MonthlyHoursUnused)
+	}
+
+	usageType := "/ElasticIP:IdleAddress/"
+	if r.CustomerOwnedIPv4Pool != "" {
+		usageType = "/CustomerOwnedIPv4Pool:IdleAddress/"
+	}
+
+	return &schema.CostComponent{
+		Name:            "IP address (if unused)",
+		Unit:            "hours",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(hours),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Region:        strPtr(r.Region),
+			Service:       strPtr("AmazonEC2"),
+			ProductFamily: strPtr("IP Address"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: strPtr(usageType)},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{
+			StartUsageAmount: strPtr("1"),
+		},
+	}
+}
+
+// additionalRemaps returns how many of this EIP's gross monthly remaps
+// (MonthlyAdditionalRemaps) are billed, i.e. the portion beyond AWS's free
+// tier of freeMonthlyRemaps per month.
+func (r *EIP) additionalRemaps() int64 {
+	if r.MonthlyAdditionalRemaps == nil {
+		return 0
+	}
+
+	remaps := *r.MonthlyAdditionalRemaps - freeMonthlyRemaps
+	if remaps < 0 {
+		return 0
+	}
+
+	return remaps
+}
+
+func (r *EIP) additionalRemapsCostComponent(remaps int64) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            "Additional remaps",
+		Unit:            "remaps",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(decimal.NewFromInt(remaps)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Region:        strPtr(r.Region),
+			Service:       strPtr("AmazonEC2"),
+			ProductFamily: strPtr("IP Address"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: strPtr("/ElasticIP:AdditionalAddress/")},
+			},
+		},
+	}
+}
+
+// dataProcessedCostComponent charges per-GB data processing for EIPs that
+// front a NAT instance or PrivateLink endpoint, where AWS bills by usage
+// type rather than by the IP address itself. A BYOIP pool address is billed
+// under a separate usage type from a standard IPv4 address.
+func (r *EIP) dataProcessedCostComponent() *schema.CostComponent {
+	usageType := "/ElasticIP:DataProcessing-Bytes/"
+	if r.CustomerOwnedIPv4Pool != "" {
+		usageType = "/CustomerOwnedIPv4Pool:DataProcessing-Bytes/"
+	}
+
+	return &schema.CostComponent{
+		Name:            "Data processed",
+		Unit:            "GB",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(decimal.NewFromFloat(*r.MonthlyDataProcessedGB)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Region:        strPtr(r.Region),
+			Service:       strPtr("AmazonEC2"),
+			ProductFamily: strPtr("Data Transfer"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: strPtr(usageType)},
 			},
-		}, UsageSchema: EIPUsageSchema,
+		},
 	}
 }
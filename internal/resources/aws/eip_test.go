@@ -0,0 +1,136 @@
+package aws
+
+import "testing"
+
+func TestEIPIsAttached(t *testing.T) {
+	tests := []struct {
+		name     string
+		eip      EIP
+		expected bool
+	}{
+		{"unattached", EIP{}, false},
+		{"attached via instance", EIP{Instance: "i-123"}, true},
+		{"attached via network interface", EIP{NetworkInterface: "eni-123"}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.eip.isAttached(); got != test.expected {
+				t.Errorf("isAttached() = %v, want %v", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestEIPBuildResourceIdleHours(t *testing.T) {
+	f64 := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name     string
+		eip      EIP
+		expected string
+	}{
+		{"unattached defaults to 730 hours", EIP{}, "730"},
+		{"attached defaults to 0 hours", EIP{Instance: "i-123"}, "0"},
+		{"attached but overridden", EIP{Instance: "i-123", MonthlyHoursUnused: f64(12)}, "12"},
+		{"unattached but overridden", EIP{MonthlyHoursUnused: f64(100)}, "100"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := test.eip.BuildResource()
+			cc := res.CostComponents[0]
+
+			if cc.MonthlyQuantity == nil {
+				t.Fatalf("expected a monthly quantity")
+			}
+			if got := cc.MonthlyQuantity.String(); got != test.expected {
+				t.Errorf("idle hours = %s, want %s", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestEIPIdleAddressUsageType(t *testing.T) {
+	tests := []struct {
+		name     string
+		eip      EIP
+		expected string
+	}{
+		{"standard IPv4", EIP{}, "/ElasticIP:IdleAddress/"},
+		{"BYOIP pool", EIP{CustomerOwnedIPv4Pool: "ipv4pool-ec2-0123abc"}, "/CustomerOwnedIPv4Pool:IdleAddress/"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := test.eip.BuildResource()
+			cc := res.CostComponents[0]
+
+			filters := cc.ProductFilter.AttributeFilters
+			if len(filters) != 1 || filters[0].Key != "usagetype" {
+				t.Fatalf("expected a single usagetype attribute filter, got %+v", filters)
+			}
+			if got := *filters[0].ValueRegex; got != test.expected {
+				t.Errorf("usagetype = %s, want %s", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestEIPDataProcessedUsageType(t *testing.T) {
+	f64 := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name     string
+		eip      EIP
+		expected string
+	}{
+		{"standard IPv4", EIP{MonthlyDataProcessedGB: f64(10)}, "/ElasticIP:DataProcessing-Bytes/"},
+		{"BYOIP pool", EIP{MonthlyDataProcessedGB: f64(10), CustomerOwnedIPv4Pool: "ipv4pool-ec2-0123abc"}, "/CustomerOwnedIPv4Pool:DataProcessing-Bytes/"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := test.eip.BuildResource()
+
+			idx := -1
+			for i, cc := range res.CostComponents {
+				if cc.Name == "Data processed" {
+					idx = i
+				}
+			}
+			if idx == -1 {
+				t.Fatalf("expected a %q cost component", "Data processed")
+			}
+
+			filters := res.CostComponents[idx].ProductFilter.AttributeFilters
+			if got := *filters[0].ValueRegex; got != test.expected {
+				t.Errorf("usagetype = %s, want %s", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestEIPAdditionalRemaps(t *testing.T) {
+	i64 := func(v int64) *int64 { return &v }
+
+	tests := []struct {
+		name     string
+		remaps   *int64
+		expected int64
+	}{
+		{"nil usage value", nil, 0},
+		{"within the free tier", i64(5), 0},
+		{"exactly the free tier", i64(freeMonthlyRemaps), 0},
+		{"beyond the free tier", i64(150), 50},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			eip := EIP{MonthlyAdditionalRemaps: test.remaps}
+			if got := eip.additionalRemaps(); got != test.expected {
+				t.Errorf("additionalRemaps() = %d, want %d", got, test.expected)
+			}
+		})
+	}
+}
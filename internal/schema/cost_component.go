@@ -0,0 +1,63 @@
+package schema
+
+import "github.com/shopspring/decimal"
+
+// CostComponent is a single priced line item that makes up a Resource, e.g.
+// "IP address (if unused)" for an EIP. ProductFilter/PriceFilter describe
+// which Cloud Pricing API price to use; prices.PopulatePrices fills in Price
+// from them before CalculateCosts runs.
+type CostComponent struct {
+	Name           string
+	Unit           string
+	UnitMultiplier decimal.Decimal
+
+	HourlyQuantity  *decimal.Decimal
+	MonthlyQuantity *decimal.Decimal
+
+	ProductFilter *ProductFilter
+	PriceFilter   *PriceFilter
+
+	// Price is the unit price resolved by prices.PopulatePrices.
+	Price decimal.Decimal
+	// MonthlyCost is populated by CalculateCosts from Price and quantity.
+	MonthlyCost *decimal.Decimal
+}
+
+func (cc *CostComponent) calculateCost() decimal.Decimal {
+	quantity := cc.MonthlyQuantity
+	if quantity == nil && cc.HourlyQuantity != nil {
+		monthly := cc.HourlyQuantity.Mul(decimal.NewFromInt(730))
+		quantity = &monthly
+	}
+
+	cost := decimal.Zero
+	if quantity != nil {
+		cost = quantity.Mul(cc.UnitMultiplier).Mul(cc.Price)
+	}
+
+	cc.MonthlyCost = &cost
+
+	return cost
+}
+
+// ProductFilter selects which Cloud Pricing API product to price a
+// CostComponent against.
+type ProductFilter struct {
+	VendorName       *string
+	Region           *string
+	Service          *string
+	ProductFamily    *string
+	AttributeFilters []*AttributeFilter
+}
+
+// AttributeFilter narrows a ProductFilter match on a single product
+// attribute, e.g. usagetype.
+type AttributeFilter struct {
+	Key        string
+	ValueRegex *string
+}
+
+// PriceFilter narrows which price tier of a matched product to use.
+type PriceFilter struct {
+	StartUsageAmount *string
+}
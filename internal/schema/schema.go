@@ -0,0 +1,94 @@
+// Package schema holds the in-memory representation of a project's
+// resources between being loaded from a plan and being rendered by
+// internal/output, plus the cost-calculation pass that runs in between.
+package schema
+
+import "github.com/shopspring/decimal"
+
+// Project is every resource detected for a single Terraform project.
+type Project struct {
+	Name      string
+	Resources []*Resource
+}
+
+// NewProject returns an empty Project ready to have resources appended.
+func NewProject() *Project {
+	return &Project{}
+}
+
+// CalculateDiff populates DiffMonthlyCost on every resource (and
+// sub-resource) now that CalculateCosts has priced them.
+func (p *Project) CalculateDiff() {
+	for _, r := range p.Resources {
+		r.calculateDiff()
+	}
+}
+
+// Resource is a single priced (or skipped/unsupported) resource.
+type Resource struct {
+	Name         string
+	ResourceType string
+
+	// NoPrice marks a resource this repo doesn't charge for, e.g. a free
+	// tier or a resource with no billable components.
+	NoPrice bool
+	// IsSkipped marks a resource we don't support pricing for at all.
+	IsSkipped   bool
+	SkipMessage string
+
+	UsageSchema    []*UsageItem
+	CostComponents []*CostComponent
+	SubResources   []*Resource
+
+	MonthlyCost *decimal.Decimal
+	// PriorMonthlyCost is the resource's cost before the change being
+	// estimated, when known; it's the baseline CalculateDiff compares
+	// against. Nil means "new resource", i.e. diff equals MonthlyCost.
+	PriorMonthlyCost *decimal.Decimal
+	DiffMonthlyCost  *decimal.Decimal
+}
+
+func (r *Resource) calculateDiff() {
+	prior := decimal.Zero
+	if r.PriorMonthlyCost != nil {
+		prior = *r.PriorMonthlyCost
+	}
+
+	monthly := decimal.Zero
+	if r.MonthlyCost != nil {
+		monthly = *r.MonthlyCost
+	}
+
+	diff := monthly.Sub(prior)
+	r.DiffMonthlyCost = &diff
+
+	for _, sr := range r.SubResources {
+		sr.calculateDiff()
+	}
+}
+
+// CalculateCosts prices every resource in project from its cost components'
+// already-populated unit prices and quantities. Fetching those unit prices
+// is prices.PopulatePrices' job, which must run before this.
+func CalculateCosts(project *Project) {
+	for _, r := range project.Resources {
+		calculateResourceCost(r)
+	}
+}
+
+func calculateResourceCost(r *Resource) {
+	total := decimal.Zero
+
+	for _, cc := range r.CostComponents {
+		total = total.Add(cc.calculateCost())
+	}
+
+	for _, sr := range r.SubResources {
+		calculateResourceCost(sr)
+		if sr.MonthlyCost != nil {
+			total = total.Add(*sr.MonthlyCost)
+		}
+	}
+
+	r.MonthlyCost = &total
+}
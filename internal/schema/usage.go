@@ -0,0 +1,37 @@
+package schema
+
+// ValueType describes the Go type a UsageItem's value should be parsed into.
+type ValueType int
+
+const (
+	String ValueType = iota
+	Float64
+	Int64
+	Bool
+)
+
+// UsageItem documents one usage-file key a resource accepts, e.g.
+// "monthly_hours_unused", along with its type and default value.
+type UsageItem struct {
+	Key          string
+	ValueType    ValueType
+	DefaultValue interface{}
+}
+
+// UsageData holds the usage-file values for a single resource address, keyed
+// by UsageItem.Key. resources.PopulateArgsWithUsage reads these into a
+// resource's `infracost_usage`-tagged fields.
+type UsageData struct {
+	Attributes map[string]interface{}
+}
+
+// Get returns the raw value for key, and whether it was set in the usage
+// file at all.
+func (u *UsageData) Get(key string) (interface{}, bool) {
+	if u == nil {
+		return nil, false
+	}
+
+	v, ok := u.Attributes[key]
+	return v, ok
+}